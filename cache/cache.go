@@ -1,6 +1,9 @@
 package cache
 
 // CacheStore is an interface for a cache store.
+//
+// Deprecated: use Store instead. CacheStore is kept around so existing
+// call sites keep compiling while they migrate to the unified interface.
 type CacheStore interface {
     // Get retrieves a value from the cache store.
     Get(key string) (any, error)
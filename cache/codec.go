@@ -0,0 +1,12 @@
+package cache
+
+// Codec marshals and unmarshals values for storage in a Store that
+// can't hold arbitrary Go values directly (e.g. Redis, which only
+// holds strings). Typed uses a Codec to round-trip values through such
+// a backend; see NewTyped.
+type Codec interface {
+	// Marshal encodes v into its wire representation.
+	Marshal(v any) ([]byte, error)
+	// Unmarshal decodes data into v, which must be a non-nil pointer.
+	Unmarshal(data []byte, v any) error
+}
@@ -0,0 +1,15 @@
+package cache
+
+import "github.com/vmihailenco/msgpack/v5"
+
+// MsgpackCodec implements Codec using MessagePack, a more compact wire
+// format than JSON for numeric- and binary-heavy values.
+type MsgpackCodec struct{}
+
+func (MsgpackCodec) Marshal(v any) ([]byte, error) {
+	return msgpack.Marshal(v)
+}
+
+func (MsgpackCodec) Unmarshal(data []byte, v any) error {
+	return msgpack.Unmarshal(data, v)
+}
@@ -0,0 +1,35 @@
+package cache
+
+import "testing"
+
+type codecTestValue struct {
+	Name  string
+	Count int
+}
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	codecs := map[string]Codec{
+		"json":    JSONCodec{},
+		"gob":     GobCodec{},
+		"msgpack": MsgpackCodec{},
+	}
+
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			in := codecTestValue{Name: "widget", Count: 3}
+
+			data, err := codec.Marshal(in)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+
+			var out codecTestValue
+			if err := codec.Unmarshal(data, &out); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if out != in {
+				t.Fatalf("round-tripped %+v, want %+v", out, in)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes a Store backend available under the given name, so it
+// can later be instantiated via NewCache. It follows the adapter
+// pattern used by database/sql: backend packages call Register from an
+// init function, and callers select a backend by name at runtime
+// instead of importing it directly.
+//
+// Register panics if called twice with the same name, or with a nil
+// factory, mirroring sql.Register.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if factory == nil {
+		panic("cache: Register factory is nil")
+	}
+	if _, dup := registry[name]; dup {
+		panic("cache: Register called twice for backend " + name)
+	}
+	registry[name] = factory
+}
+
+// NewCache builds a Store for the named backend (e.g. "memory",
+// "redis") using configJSON as its backend-specific configuration.
+//
+// Parameters:
+//
+//	name string: the registered backend name.
+//	configJSON string: a JSON document decoded by the backend's factory.
+//
+// Returns:
+//
+//	(Store, error): the constructed store, or an error if the backend is
+//	unknown or the configuration could not be applied.
+func NewCache(name string, configJSON string) (Store, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("cache: unknown backend %q (forgotten import?)", name)
+	}
+	return factory(configJSON)
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeStore struct{}
+
+func (fakeStore) Get(key string) (any, error)                            { return nil, ErrNotFound }
+func (fakeStore) Set(key string, value any) error                        { return nil }
+func (fakeStore) SetEx(key string, value any, expiration time.Duration) error { return nil }
+func (fakeStore) Delete(key string) error                                { return nil }
+func (fakeStore) Exists(key string) (bool, error)                        { return false, nil }
+func (fakeStore) MGet(keys ...string) (map[string]any, error)            { return nil, nil }
+func (fakeStore) MDel(keys ...string) (int, error)                       { return 0, nil }
+func (fakeStore) Clear() error                                           { return nil }
+
+func TestRegisterAndNewCache(t *testing.T) {
+	const name = "registry-test-backend"
+	var gotConfig string
+
+	Register(name, func(configJSON string) (Store, error) {
+		gotConfig = configJSON
+		return fakeStore{}, nil
+	})
+
+	store, err := NewCache(name, `{"k":"v"}`)
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	if _, ok := store.(fakeStore); !ok {
+		t.Fatalf("NewCache returned %T, want fakeStore", store)
+	}
+	if gotConfig != `{"k":"v"}` {
+		t.Fatalf("factory received configJSON %q, want %q", gotConfig, `{"k":"v"}`)
+	}
+}
+
+func TestRegister_PanicsOnDuplicateName(t *testing.T) {
+	const name = "registry-test-duplicate"
+	Register(name, func(string) (Store, error) { return fakeStore{}, nil })
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Register did not panic on duplicate name")
+		}
+	}()
+	Register(name, func(string) (Store, error) { return fakeStore{}, nil })
+}
+
+func TestRegister_PanicsOnNilFactory(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Register did not panic on nil factory")
+		}
+	}()
+	Register("registry-test-nil-factory", nil)
+}
+
+func TestNewCache_UnknownBackend(t *testing.T) {
+	if _, err := NewCache("registry-test-unknown-backend", ""); err == nil {
+		t.Fatal("NewCache with unknown backend name returned nil error")
+	}
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by Store implementations when a key does not
+// exist or has expired.
+var ErrNotFound = errors.New("cache: key not found")
+
+// ErrClosed is returned when an operation is attempted on a Store that
+// has already been closed.
+var ErrClosed = errors.New("cache: store closed")
+
+// Store is the unified interface implemented by every cache backend
+// (memory, redis, and future adapters such as memcache or file). It
+// supersedes CacheStore, which only a subset of backends implemented.
+//
+// Implementations must return ErrNotFound from Get/MGet when a key is
+// absent or expired, rather than a nil value with a nil error.
+type Store interface {
+	// Get retrieves the value stored under key. It returns ErrNotFound
+	// if the key does not exist or has expired.
+	Get(key string) (any, error)
+	// Set stores value under key using the backend's default expiration.
+	Set(key string, value any) error
+	// SetEx stores value under key with an explicit expiration.
+	SetEx(key string, value any, expiration time.Duration) error
+	// Delete removes key from the store. It is not an error to delete a
+	// key that does not exist.
+	Delete(key string) error
+	// Exists reports whether key is present in the store.
+	Exists(key string) (bool, error)
+	// MGet retrieves the values stored under the given keys. Missing
+	// keys are omitted from the result map rather than causing an error.
+	MGet(keys ...string) (map[string]any, error)
+	// MDel removes the given keys from the store and returns the number
+	// of keys that were actually deleted.
+	MDel(keys ...string) (int, error)
+	// Clear removes every entry from the store.
+	Clear() error
+}
+
+// Factory builds a Store from a backend-specific JSON configuration
+// blob. Each adapter package registers its own Factory via Register.
+type Factory func(configJSON string) (Store, error)
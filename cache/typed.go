@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+)
+
+// Typed wraps a Store with a typed Get/Set API, so callers stop
+// round-tripping any through a backend's raw API and re-asserting the
+// type on every read.
+//
+// If codec is nil, Typed stores values as T directly: this is the
+// right choice for backends that hold arbitrary Go values in process,
+// such as memory.Cache. If codec is non-nil, values are marshaled
+// before Set and unmarshaled after Get: this is required for backends
+// that only hold strings or bytes, such as redis.CacheConn.
+type Typed[T any] struct {
+	store Store
+	codec Codec
+}
+
+// NewTyped wraps store with a typed API for T, using codec to
+// serialize values. Pass a nil codec when store holds Go values
+// natively (e.g. the in-memory backend).
+//
+// Parameters:
+//
+//	store Store: the backend to wrap.
+//	codec Codec: the serializer to use, or nil to store values as-is.
+//
+// Returns:
+//
+//	*Typed[T]: the typed wrapper.
+func NewTyped[T any](store Store, codec Codec) *Typed[T] {
+	return &Typed[T]{store: store, codec: codec}
+}
+
+// Get retrieves the value stored under key.
+//
+// Parameters:
+//
+//	key string: the key to retrieve.
+//
+// Returns:
+//
+//	(T, bool, error): the value and whether it was found, or an error
+//	if the lookup or decoding failed. A miss is reported as (zero value,
+//	false, nil), not an error.
+func (t *Typed[T]) Get(key string) (T, bool, error) {
+	var zero T
+
+	raw, err := t.store.Get(key)
+	if err == ErrNotFound {
+		return zero, false, nil
+	}
+	if err != nil {
+		return zero, false, err
+	}
+
+	if t.codec == nil {
+		value, ok := raw.(T)
+		if !ok {
+			return zero, false, fmt.Errorf("cache: value for key %q is %T, not %T", key, raw, zero)
+		}
+		return value, true, nil
+	}
+
+	data, err := toBytes(raw)
+	if err != nil {
+		return zero, false, err
+	}
+	var value T
+	if err := t.codec.Unmarshal(data, &value); err != nil {
+		return zero, false, err
+	}
+	return value, true, nil
+}
+
+// Set stores val under key using the backend's default expiration.
+//
+// Parameters:
+//
+//	key string: the key to store.
+//	val T: the value to store.
+//
+// Returns:
+//
+//	error: an error if storing or encoding the value failed.
+func (t *Typed[T]) Set(key string, val T) error {
+	return t.SetEx(key, val, 0)
+}
+
+// SetEx stores val under key with an explicit expiration. A zero ttl
+// uses the backend's default expiration, matching Store.Set.
+//
+// Parameters:
+//
+//	key string: the key to store.
+//	val T: the value to store.
+//	ttl time.Duration: the expiration to apply, or 0 for the default.
+//
+// Returns:
+//
+//	error: an error if storing or encoding the value failed.
+func (t *Typed[T]) SetEx(key string, val T, ttl time.Duration) error {
+	if t.codec == nil {
+		if ttl > 0 {
+			return t.store.SetEx(key, val, ttl)
+		}
+		return t.store.Set(key, val)
+	}
+
+	data, err := t.codec.Marshal(val)
+	if err != nil {
+		return err
+	}
+	if ttl > 0 {
+		return t.store.SetEx(key, data, ttl)
+	}
+	return t.store.Set(key, data)
+}
+
+// toBytes extracts the wire bytes from a raw Store value, which may
+// come back as either a []byte or a string depending on the backend.
+func toBytes(raw any) ([]byte, error) {
+	switch v := raw.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return nil, fmt.Errorf("cache: unexpected raw value type %T", raw)
+	}
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+// memStore is a minimal in-process Store for exercising Typed without
+// depending on the memory or redis packages (which would import cache
+// and create a cycle).
+type memStore struct {
+	values map[string]any
+}
+
+func newMemStore() *memStore { return &memStore{values: make(map[string]any)} }
+
+func (m *memStore) Get(key string) (any, error) {
+	v, ok := m.values[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return v, nil
+}
+func (m *memStore) Set(key string, value any) error { m.values[key] = value; return nil }
+func (m *memStore) SetEx(key string, value any, expiration time.Duration) error {
+	return m.Set(key, value)
+}
+func (m *memStore) Delete(key string) error { delete(m.values, key); return nil }
+func (m *memStore) Exists(key string) (bool, error) {
+	_, ok := m.values[key]
+	return ok, nil
+}
+func (m *memStore) MGet(keys ...string) (map[string]any, error) {
+	result := make(map[string]any, len(keys))
+	for _, k := range keys {
+		if v, ok := m.values[k]; ok {
+			result[k] = v
+		}
+	}
+	return result, nil
+}
+func (m *memStore) MDel(keys ...string) (int, error) {
+	deleted := 0
+	for _, k := range keys {
+		if _, ok := m.values[k]; ok {
+			delete(m.values, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+func (m *memStore) Clear() error { m.values = make(map[string]any); return nil }
+
+func TestTyped_NoCodec_StoresValueDirectly(t *testing.T) {
+	typed := NewTyped[int](newMemStore(), nil)
+
+	if err := typed.Set("k", 42); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	value, found, err := typed.Get("k")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !found {
+		t.Fatal("Get: found = false, want true")
+	}
+	if value != 42 {
+		t.Fatalf("Get = %d, want 42", value)
+	}
+}
+
+func TestTyped_WithCodec_RoundTripsThroughBytes(t *testing.T) {
+	typed := NewTyped[codecTestValue](newMemStore(), JSONCodec{})
+	in := codecTestValue{Name: "widget", Count: 7}
+
+	if err := typed.SetEx("k", in, time.Minute); err != nil {
+		t.Fatalf("SetEx: %v", err)
+	}
+	out, found, err := typed.Get("k")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !found {
+		t.Fatal("Get: found = false, want true")
+	}
+	if out != in {
+		t.Fatalf("Get = %+v, want %+v", out, in)
+	}
+}
+
+func TestTyped_Get_MissReturnsNotFoundAsFalse(t *testing.T) {
+	typed := NewTyped[string](newMemStore(), nil)
+
+	value, found, err := typed.Get("missing")
+	if err != nil {
+		t.Fatalf("Get: unexpected error %v", err)
+	}
+	if found {
+		t.Fatal("Get: found = true for a missing key")
+	}
+	if value != "" {
+		t.Fatalf("Get: value = %q for a missing key, want zero value", value)
+	}
+}
@@ -0,0 +1,100 @@
+package file
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+type cleaner struct {
+	Interval time.Duration
+	stop     chan bool
+}
+
+// Run periodically sweeps the cache directory for expired entries and,
+// if MaxBytes is set, evicts the least recently used entries until the
+// cache is back under budget. It is the disk-backed analogue of
+// memory.cleaner.Run.
+func (cl *cleaner) Run(c *Cache) {
+	ticker := time.NewTicker(cl.Interval)
+	for {
+		select {
+		case <-ticker.C:
+			c.sweep()
+		case <-cl.stop:
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+type entryInfo struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// sweep removes expired entries, then evicts by least recently used
+// (approximated by file modification time, bumped on every read) until
+// total size is back under opts.MaxBytes.
+func (c *Cache) sweep() {
+	var entries []entryInfo
+	var total int64
+
+	_ = filepath.Walk(c.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info == nil || info.IsDir() {
+			return nil
+		}
+
+		if expired := c.removeIfExpired(path); expired {
+			return nil
+		}
+
+		entries = append(entries, entryInfo{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+		return nil
+	})
+
+	if c.opts.MaxBytes <= 0 || total <= c.opts.MaxBytes {
+		return
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+
+	for _, e := range entries {
+		if total <= c.opts.MaxBytes {
+			break
+		}
+		if err := os.Remove(e.path); err != nil {
+			continue
+		}
+		total -= e.size
+	}
+}
+
+// removeIfExpired deletes path if its header says it has expired, and
+// reports whether it did so.
+func (c *Cache) removeIfExpired(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return false
+	}
+	expire := int64(binary.BigEndian.Uint64(header[0:8]))
+	if expire == 0 || time.Now().UnixNano() <= expire {
+		return false
+	}
+
+	f.Close()
+	return os.Remove(path) == nil
+}
@@ -0,0 +1,241 @@
+// Package file implements a durable cache.Store backed by the local
+// filesystem. Entries are sharded across subdirectories by a hash of
+// their key to avoid a single directory holding huge numbers of files,
+// and survive process restarts, making it a third adapter alongside
+// memory and redis for blobs too large or too important to lose on
+// restart.
+package file
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/isofinly/cachease/cache"
+)
+
+const (
+	// DEFAULT_EXPIRATION defines the default ttl time period for all keys.
+	DEFAULT_EXPIRATION = 10 * time.Minute
+
+	// DEFAULT_CLEAN_DURATION defines the default period of the auto clean.
+	DEFAULT_CLEAN_DURATION = 10 * time.Minute
+
+	// headerSize is the fixed on-disk size of the entry header:
+	// 8 bytes expiration (unix nano) + 4 bytes value length + 1 byte codec id.
+	headerSize = 8 + 4 + 1
+)
+
+// FsyncPolicy controls how aggressively Cache flushes writes to disk.
+type FsyncPolicy int
+
+const (
+	// FsyncNever relies on the OS page cache to flush writes eventually.
+	// It is the fastest policy and the default.
+	FsyncNever FsyncPolicy = iota
+	// FsyncAlways calls File.Sync after every write, trading throughput
+	// for a guarantee that a Set has reached disk before it returns.
+	FsyncAlways
+)
+
+// FileOpts configures a Cache.
+type FileOpts struct {
+	// MaxBytes bounds the total size of all entries on disk. When
+	// exceeded, the background cleaner evicts the least recently
+	// accessed entries until the cache is back under budget. Zero
+	// means unbounded.
+	MaxBytes int64
+	// Fsync controls how writes are flushed to disk. Defaults to
+	// FsyncNever.
+	Fsync FsyncPolicy
+	// Codec serializes values for storage. Defaults to cache.JSONCodec{}.
+	//
+	// cache.GobCodec is not supported here: gob requires the decode
+	// target's concrete type to match what was encoded, but Store.Get
+	// only has an untyped any to decode into. cache.Typed[T] with
+	// cache.GobCodec works over a Cache whose Codec is
+	// cache.MsgpackCodec, which hands the gob bytes back as a []byte;
+	// over the default cache.JSONCodec they come back as a base64
+	// string, which Typed does not decode.
+	Codec cache.Codec
+	// DefaultExpiration is applied to entries written via Set. Defaults
+	// to DEFAULT_EXPIRATION.
+	DefaultExpiration time.Duration
+	// CleanDuration controls how often the background cleaner sweeps
+	// expired and over-budget entries. Defaults to DEFAULT_CLEAN_DURATION.
+	CleanDuration time.Duration
+}
+
+// Cache is a durable, disk-backed cache.Store.
+type Cache struct {
+	dir               string
+	opts              FileOpts
+	defaultExpiration time.Duration
+	cleaner           *cleaner
+	closeOnce         sync.Once
+}
+
+// NewFileCache creates a Cache rooted at dir, creating it if necessary.
+//
+// Parameters:
+//
+//	dir string: the root directory entries are persisted under.
+//	opts FileOpts: tuning options; the zero value is a valid default.
+//
+// Returns:
+//
+//	(*Cache, error): the cache, or an error if dir could not be created.
+func NewFileCache(dir string, opts FileOpts) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("file: creating cache dir: %w", err)
+	}
+	if opts.Codec == nil {
+		opts.Codec = cache.JSONCodec{}
+	}
+	if _, isGob := opts.Codec.(cache.GobCodec); isGob {
+		return nil, fmt.Errorf("file: GobCodec cannot round-trip through Store.Get's untyped any; use cache.Typed[T] with cache.GobCodec over a Cache using cache.MsgpackCodec instead")
+	}
+	if opts.DefaultExpiration == 0 {
+		opts.DefaultExpiration = DEFAULT_EXPIRATION
+	}
+	if opts.CleanDuration == 0 {
+		opts.CleanDuration = DEFAULT_CLEAN_DURATION
+	}
+
+	c := &Cache{
+		dir:               dir,
+		opts:              opts,
+		defaultExpiration: opts.DefaultExpiration,
+		cleaner: &cleaner{
+			Interval: opts.CleanDuration,
+			stop:     make(chan bool),
+		},
+	}
+
+	go c.cleaner.Run(c)
+	return c, nil
+}
+
+// Close stops the background cleaner. Cache is not usable after Close.
+// It is safe to call more than once; only the first call stops the
+// cleaner.
+func (c *Cache) Close() {
+	c.closeOnce.Do(func() {
+		c.cleaner.stop <- true
+	})
+}
+
+// pathFor returns the sharded on-disk path for key: dir/xx/yy/<hash>,
+// where xx and yy are the first two byte-pairs of the hex-encoded
+// SHA-256 hash of key, so no single directory ends up holding every
+// entry.
+func (c *Cache) pathFor(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	hash := hex.EncodeToString(sum[:])
+	return filepath.Join(c.dir, hash[0:2], hash[2:4], hash)
+}
+
+// writeEntry persists value (already encoded by the configured Codec)
+// under key with the given expiration, via a temp file plus rename so
+// a crash mid-write can't leave a half-written entry visible.
+func (c *Cache) writeEntry(key string, data []byte, expire int64) error {
+	path := c.pathFor(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once renamed
+
+	header := make([]byte, headerSize)
+	binary.BigEndian.PutUint64(header[0:8], uint64(expire))
+	binary.BigEndian.PutUint32(header[8:12], uint32(len(data)))
+	header[12] = codecID(c.opts.Codec)
+
+	if _, err := tmp.Write(header); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if c.opts.Fsync == FsyncAlways {
+		if err := tmp.Sync(); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// readEntry reads the entry stored under key and returns its encoded
+// value, leaving decoding to the caller. It returns
+// cache.ErrNotFound if the entry is missing or has expired, removing
+// expired entries as it finds them.
+func (c *Cache) readEntry(key string) ([]byte, error) {
+	path := c.pathFor(key)
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, cache.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return nil, cache.ErrNotFound
+	}
+	expire := int64(binary.BigEndian.Uint64(header[0:8]))
+	length := binary.BigEndian.Uint32(header[8:12])
+
+	if expire > 0 && time.Now().UnixNano() > expire {
+		f.Close()
+		os.Remove(path)
+		return nil, cache.ErrNotFound
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, err
+	}
+
+	// Bump mtime so the LRU-by-access-time sweep in the background
+	// cleaner treats this entry as recently used.
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return data, nil
+}
+
+// codecID returns a small tag identifying codec, recorded in each
+// entry's header for debugging and forward compatibility. It isn't
+// consulted on read: a Cache always decodes with its own configured
+// Codec.
+func codecID(codec cache.Codec) byte {
+	switch codec.(type) {
+	case cache.JSONCodec:
+		return 1
+	case cache.GobCodec:
+		return 2
+	case cache.MsgpackCodec:
+		return 3
+	default:
+		return 0
+	}
+}
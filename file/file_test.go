@@ -0,0 +1,104 @@
+package file
+
+import (
+	"testing"
+	"time"
+
+	"github.com/isofinly/cachease/cache"
+)
+
+func TestCache_SetGetDeleteExists(t *testing.T) {
+	c, err := NewFileCache(t.TempDir(), FileOpts{})
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.Set("k", "value"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, err := c.Get("k")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got != "value" {
+		t.Fatalf("Get = %v, want %q", got, "value")
+	}
+
+	if found, err := c.Exists("k"); err != nil || !found {
+		t.Fatalf("Exists = (%v, %v), want (true, nil)", found, err)
+	}
+
+	if err := c.Delete("k"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := c.Get("k"); err != cache.ErrNotFound {
+		t.Fatalf("Get after Delete: got err %v, want cache.ErrNotFound", err)
+	}
+	if found, err := c.Exists("k"); err != nil || found {
+		t.Fatalf("Exists after Delete = (%v, %v), want (false, nil)", found, err)
+	}
+}
+
+func TestCache_SetEx_ExpiresEntries(t *testing.T) {
+	c, err := NewFileCache(t.TempDir(), FileOpts{})
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.SetEx("k", "value", time.Millisecond); err != nil {
+		t.Fatalf("SetEx: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	if _, err := c.Get("k"); err != cache.ErrNotFound {
+		t.Fatalf("Get of expired entry: got err %v, want cache.ErrNotFound", err)
+	}
+}
+
+func TestCache_Sweep_EvictsLeastRecentlyUsedOverBudget(t *testing.T) {
+	c, err := NewFileCache(t.TempDir(), FileOpts{})
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	defer c.Close()
+
+	// Each entry is headerSize + len(`"value-N"`) bytes; budget for two.
+	entrySize := int64(headerSize + len(`"value-0"`))
+	c.opts.MaxBytes = 2 * entrySize
+
+	for i, key := range []string{"a", "b", "c"} {
+		if err := c.Set(key, "value-"+string(rune('0'+i))); err != nil {
+			t.Fatalf("Set(%q): %v", key, err)
+		}
+		time.Sleep(10 * time.Millisecond) // distinct mtimes for the LRU sweep
+	}
+
+	c.sweep()
+
+	if _, err := c.Get("a"); err != cache.ErrNotFound {
+		t.Fatalf("Get(a) after sweep: got err %v, want cache.ErrNotFound (least recently used)", err)
+	}
+	for _, key := range []string{"b", "c"} {
+		if _, err := c.Get(key); err != nil {
+			t.Fatalf("Get(%q) after sweep: unexpected error %v", key, err)
+		}
+	}
+}
+
+func TestNewFileCache_RejectsGobCodec(t *testing.T) {
+	if _, err := NewFileCache(t.TempDir(), FileOpts{Codec: cache.GobCodec{}}); err == nil {
+		t.Fatal("NewFileCache with GobCodec returned nil error, want a rejection")
+	}
+}
+
+func TestCache_Close_Idempotent(t *testing.T) {
+	c, err := NewFileCache(t.TempDir(), FileOpts{})
+	if err != nil {
+		t.Fatalf("NewFileCache: %v", err)
+	}
+	c.Close()
+	c.Close()
+}
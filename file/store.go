@@ -0,0 +1,146 @@
+package file
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/isofinly/cachease/cache"
+)
+
+// Config is the typed configuration accepted by the "file" backend
+// factory registered with cache.Register.
+type Config struct {
+	// Dir is the root directory entries are persisted under.
+	Dir string `json:"dir"`
+	// MaxBytes bounds the total size of all entries on disk. Zero means
+	// unbounded.
+	MaxBytes int64 `json:"maxBytes"`
+	// Fsync controls how writes are flushed to disk.
+	Fsync FsyncPolicy `json:"fsync"`
+	// DefaultExpiration is applied to entries written via Set. Defaults
+	// to DEFAULT_EXPIRATION.
+	DefaultExpiration time.Duration `json:"defaultExpiration"`
+	// CleanDuration controls how often the background cleaner sweeps.
+	// Defaults to DEFAULT_CLEAN_DURATION.
+	CleanDuration time.Duration `json:"cleanDuration"`
+}
+
+func init() {
+	cache.Register("file", newFromConfigJSON)
+}
+
+func newFromConfigJSON(configJSON string) (cache.Store, error) {
+	cfg := Config{
+		DefaultExpiration: DEFAULT_EXPIRATION,
+		CleanDuration:     DEFAULT_CLEAN_DURATION,
+	}
+	if configJSON != "" {
+		if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+			return nil, err
+		}
+	}
+	return NewFileCache(cfg.Dir, FileOpts{
+		MaxBytes:          cfg.MaxBytes,
+		Fsync:             cfg.Fsync,
+		DefaultExpiration: cfg.DefaultExpiration,
+		CleanDuration:     cfg.CleanDuration,
+	})
+}
+
+// Get retrieves the value stored under key. It implements cache.Store.
+func (c *Cache) Get(key string) (any, error) {
+	data, err := c.readEntry(key)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err := c.opts.Codec.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Set stores value under key using the cache's default expiration. It
+// implements cache.Store.
+func (c *Cache) Set(key string, value any) error {
+	return c.SetEx(key, value, c.defaultExpiration)
+}
+
+// SetEx stores value under key with an explicit expiration, overriding
+// the cache's default. It implements cache.Store.
+func (c *Cache) SetEx(key string, value any, expiration time.Duration) error {
+	data, err := c.opts.Codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+	expire := time.Now().Add(expiration).UnixNano()
+	return c.writeEntry(key, data, expire)
+}
+
+// Delete removes key from the cache. It implements cache.Store.
+func (c *Cache) Delete(key string) error {
+	err := os.Remove(c.pathFor(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Exists reports whether key is present and unexpired. It implements
+// cache.Store.
+func (c *Cache) Exists(key string) (bool, error) {
+	_, err := c.readEntry(key)
+	if err == cache.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// MGet retrieves the values stored under the given keys. Missing keys
+// are omitted from the result. It implements cache.Store.
+func (c *Cache) MGet(keys ...string) (map[string]any, error) {
+	result := make(map[string]any, len(keys))
+	for _, key := range keys {
+		value, err := c.Get(key)
+		if err == cache.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// MDel removes the given keys from the cache and returns the number of
+// keys that were actually present. It implements cache.Store.
+func (c *Cache) MDel(keys ...string) (int, error) {
+	deleted := 0
+	for _, key := range keys {
+		found, err := c.Exists(key)
+		if err != nil {
+			return deleted, err
+		}
+		if !found {
+			continue
+		}
+		if err := c.Delete(key); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// Clear removes every entry from the cache. It implements cache.Store.
+func (c *Cache) Clear() error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return err
+	}
+	return os.MkdirAll(c.dir, 0o755)
+}
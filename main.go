@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -22,8 +23,9 @@ func main() {
 	val, _ := c.Get("test")
 	fmt.Printf("val: %s\n", val)
 
-	redis.Cache.SetDetails("test2", "aboba2")
-	redis.Cache.SetDetails("test3", "aboba3")
-	res, _ := redis.Cache.GetManyDetails("test2", "test3")
+	ctx := context.Background()
+	redis.Cache.SetDetails(ctx, "test2", "aboba2")
+	redis.Cache.SetDetails(ctx, "test3", "aboba3")
+	res, _ := redis.Cache.GetManyDetails(ctx, "test2", "test3")
 	fmt.Printf("res: %v\n", res)
 }
@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/isofinly/cachease/cache"
+)
+
+func TestCache_LRUEvictionOrder(t *testing.T) {
+	c, err := newCache(3, DEFAULT_EXPIRATION, time.Hour)
+	if err != nil {
+		t.Fatalf("newCache: %v", err)
+	}
+
+	mustPut := func(key string, value any) {
+		t.Helper()
+		if err := c.Put(key, value); err != nil {
+			t.Fatalf("Put(%q): %v", key, err)
+		}
+	}
+
+	mustPut("a", 1)
+	mustPut("b", 2)
+	mustPut("c", 3)
+
+	// Touch "a" so it's most recently used; "b" becomes the LRU victim.
+	if _, err := c.Get("a"); err != nil {
+		t.Fatalf("Get(a): %v", err)
+	}
+
+	// Over capacity: evicts the current LRU entry, "b".
+	mustPut("d", 4)
+
+	if _, err := c.Get("b"); err != cache.ErrNotFound {
+		t.Fatalf("Get(b) after eviction: got err %v, want cache.ErrNotFound", err)
+	}
+	for _, key := range []string{"a", "c", "d"} {
+		if _, err := c.Get(key); err != nil {
+			t.Fatalf("Get(%q): unexpected error %v", key, err)
+		}
+	}
+}
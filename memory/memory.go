@@ -1,10 +1,13 @@
 package memory
 
 import (
-	"math/bits"
+	"container/list"
 	"runtime"
 	"sync"
 	"time"
+
+	"github.com/isofinly/cachease/cache"
+	"github.com/isofinly/cachease/singleflight"
 )
 
 const (
@@ -16,20 +19,26 @@ const (
 
 	// Const DEFAULT_CAP defines the default size of the cache
 	DEFAULT_CAP = 1024
-
-	// Const DEFAULT_LRU_CLEAN_SIZE defines the default number of keys that are cleaned during auto clean
-	DEFAULT_LRU_CLEAN_SIZE = 20
 )
 
 // Cache defines the structure of the Cache.
+//
+// Entries are kept in a doubly-linked list ordered by the active
+// EvictionPolicy, with elements addressed by a map for O(1) lookup.
+// Get, Put and Remove are therefore all O(1), unlike the earlier
+// implementation which scanned every element to find an eviction
+// candidate.
 type Cache struct {
 	defaultExpiration time.Duration
-	elements          map[string]Elem
+	ll                *list.List
+	elements          map[string]*list.Element
 	capacity          int64
 	size              int64
 	lock              *sync.RWMutex
-	pool              *sync.Pool
+	elemPool          *sync.Pool
+	policy            EvictionPolicy
 	cleaner           *cleaner
+	loads             singleflight.Group
 }
 
 // Elem defines the item of the Cache value.
@@ -46,9 +55,16 @@ type cleaner struct {
 	stop     chan bool
 }
 
+// SetEvictionPolicy swaps the strategy used to pick an eviction
+// candidate when the cache is at capacity. It must be called before
+// the cache is used concurrently; the default is an LRU policy.
+func (c *Cache) SetEvictionPolicy(policy EvictionPolicy) {
+	c.policy = policy
+}
+
 // Get retrieves the value associated with the given key from the cache.
 //
-// If the key is not found in the cache, nil is returned.
+// If the key is not found in the cache, cache.ErrNotFound is returned.
 //
 // Parameters:
 //
@@ -56,24 +72,62 @@ type cleaner struct {
 //
 // Returns:
 //
-//	(interface{}, error): The value associated with the key, or nil if the key is not found.
+//	(interface{}, error): The value associated with the key, or cache.ErrNotFound
+//	if the key is not found or has expired.
 func (c *Cache) Get(key string) (value any, err error) {
-	ele := c.pool.Get()
-	if item, ok := ele.(Elem); ok {
-		if item.K == key {
-			return item.V, nil
-		}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	node, ok := c.elements[key]
+	if !ok {
+		return nil, cache.ErrNotFound
 	}
-	expire := time.Now().Add(DEFAULT_EXPIRATION).UnixNano()
-	lastHit := time.Now().UnixNano()
-	c.lock.RLock()
-	defer c.lock.RUnlock()
-	if ele, ok := c.elements[key]; ok {
-		ele.Expiration = expire
-		ele.LastHit = lastHit
-		return ele.V, nil
+
+	ele := node.Value.(*Elem)
+	now := time.Now().UnixNano()
+	if ele.Expiration > 0 && now > ele.Expiration {
+		c.removeElement(node)
+		return nil, cache.ErrNotFound
+	}
+	ele.LastHit = now
+	c.policy.Touch(c.ll, node)
+	return ele.V, nil
+}
+
+// GetOrLoad retrieves the value for key, calling loader to produce it
+// on a cache miss and storing the result. Concurrent GetOrLoad calls
+// for the same key are coalesced so loader runs at most once at a
+// time; the rest of the callers block and share its result, preventing
+// a thundering herd against whatever loader fetches from.
+//
+// Parameters:
+//
+//	key (string): The key to retrieve or populate.
+//	loader (func() (any, error)): Invoked on a cache miss to produce the value.
+//
+// Returns:
+//
+//	(any, error): The cached or freshly loaded value, or an error if
+//	the cache lookup or loader failed.
+func (c *Cache) GetOrLoad(key string, loader func() (any, error)) (any, error) {
+	if value, err := c.Get(key); err == nil {
+		return value, nil
 	}
-	return nil, nil
+
+	return c.loads.Do(key, func() (any, error) {
+		if value, err := c.Get(key); err == nil {
+			return value, nil
+		}
+
+		value, err := loader()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Put(key, value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	})
 }
 
 // Put an element into the cache.
@@ -87,74 +141,64 @@ func (c *Cache) Get(key string) (value any, err error) {
 //
 //	(error): An error if one occurred.
 func (c *Cache) Put(key string, value any) error {
-	expire := time.Now().Add(DEFAULT_EXPIRATION).UnixNano()
+	expire := time.Now().Add(c.defaultExpiration).UnixNano()
 	lastHit := time.Now().UnixNano()
-	if c.size+1 > c.capacity {
-		// LRU kicks in
-		if err := c.removeLeastVisited(); err != nil {
-			return err
-		}
-	}
+
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	if found := c.update(key, value, expire, lastHit); found {
+	if node, ok := c.elements[key]; ok {
+		ele := node.Value.(*Elem)
+		ele.V = value
+		ele.Expiration = expire
+		ele.LastHit = lastHit
+		c.policy.Touch(c.ll, node)
 		return nil
 	}
 
-	ele := Elem{
-		V:          value,
-		Expiration: expire,
-		LastHit:    lastHit,
+	if c.size+1 > c.capacity {
+		c.evict()
 	}
-	c.pool.Put(&ele)
-	c.elements[key] = ele
-	c.size = c.size + 1
+
+	ele := c.newElem(key, value, expire, lastHit)
+	node := c.ll.PushFront(ele)
+	c.elements[key] = node
+	c.size++
 	return nil
 }
 
-func (c *Cache) update(k string, v interface{}, expire int64, lastHit int64) bool {
-	if ele, ok := c.elements[k]; ok {
-		ele.V = v
-		ele.Expiration = expire
-		ele.LastHit = lastHit
-		return true
+// newElem returns an *Elem, reusing one from elemPool when available
+// instead of allocating.
+func (c *Cache) newElem(key string, value any, expire, lastHit int64) *Elem {
+	ele, _ := c.elemPool.Get().(*Elem)
+	if ele == nil {
+		ele = &Elem{}
 	}
-	return false
+	ele.K = key
+	ele.V = value
+	ele.Expiration = expire
+	ele.LastHit = lastHit
+	return ele
 }
 
-func (c *Cache) removeLeastVisited() error {
-	c.lock.Lock()
-	defer c.lock.Unlock()
-
-	var lastTime int64 = 1<<(bits.UintSize-1) - 1 // MaxInt
-	t := time.Now().UnixNano()
-	lastItems := make([]string, DEFAULT_LRU_CLEAN_SIZE)
-	liCount := 0
-	full := false
-
-	for k, v := range c.elements {
-		if v.Expiration > t { // not expiring
-			atime := v.LastHit
-			if !full || atime < lastTime {
-				lastTime = atime
-				if liCount < DEFAULT_LRU_CLEAN_SIZE {
-					lastItems[liCount] = k
-					liCount++
-				} else {
-					lastItems[0] = k
-					liCount = 1
-					full = true
-				}
-			}
-		}
+// evict removes the current policy's victim from the cache. Callers
+// must hold c.lock.
+func (c *Cache) evict() {
+	victim := c.policy.Victim(c.ll)
+	if victim == nil {
+		return
 	}
+	c.removeElement(victim)
+}
 
-	for i := 0; i < len(lastItems) && lastItems[i] != ""; i++ {
-		lastName := lastItems[i]
-		delete(c.elements, lastName)
-	}
-	return nil
+// removeElement unlinks node from the list and the lookup map, and
+// returns its *Elem to elemPool. Callers must hold c.lock.
+func (c *Cache) removeElement(node *list.Element) {
+	ele := node.Value.(*Elem)
+	c.ll.Remove(node)
+	delete(c.elements, ele.K)
+	c.size--
+	c.elemPool.Put(ele)
 }
 
 // Remove the element with the given key from the cache.
@@ -169,25 +213,22 @@ func (c *Cache) removeLeastVisited() error {
 func (c *Cache) Remove(key string) (isFound bool, err error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	v := c.pool.Get()
-	if v != nil && v.(Elem).K != key {
-		c.pool.Put(v)
-	}
-	for k := range c.elements {
-		if k == key {
-			delete(c.elements, key)
-			return true, nil
-		}
+
+	node, ok := c.elements[key]
+	if !ok {
+		return false, nil
 	}
-	return false, nil
+	c.removeElement(node)
+	return true, nil
 }
 
 // Flush cleans up the cache
 func (c *Cache) Flush() error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	c.pool.Get()
-	c.elements = make(map[string]Elem, DEFAULT_CAP)
+	c.ll = list.New()
+	c.elements = make(map[string]*list.Element, DEFAULT_CAP)
+	c.size = 0
 	return nil
 }
 
@@ -198,9 +239,10 @@ func (c *Cache) RemoveExpired() {
 	now := time.Now().UnixNano()
 	c.lock.Lock()
 	defer c.lock.Unlock()
-	for k, v := range c.elements {
-		if v.Expiration > 0 && now > v.Expiration {
-			_, _ = c.Remove(k)
+	for _, node := range c.elements {
+		ele := node.Value.(*Elem)
+		if ele.Expiration > 0 && now > ele.Expiration {
+			c.removeElement(node)
 		}
 	}
 }
@@ -253,14 +295,16 @@ func NewCache(params ...int) (*Cache, error) {
 func newCache(cap int64, expiration time.Duration, clean_duration time.Duration) (*Cache, error) {
 	c := &Cache{
 		defaultExpiration: expiration,
-		elements:          make(map[string]Elem, cap),
+		ll:                list.New(),
+		elements:          make(map[string]*list.Element, cap),
 		capacity:          cap,
 		lock:              new(sync.RWMutex),
+		elemPool:          &sync.Pool{},
+		policy:            lruPolicy{},
 		cleaner: &cleaner{
 			Interval: clean_duration,
 			stop:     make(chan bool),
 		},
-		pool: &sync.Pool{},
 	}
 
 	go c.cleaner.Run(c)
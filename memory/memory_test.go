@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCache_GetOrLoad_CoalescesConcurrentMisses(t *testing.T) {
+	c, err := NewCache()
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+
+	var calls int32
+	var start sync.WaitGroup
+	var done sync.WaitGroup
+	start.Add(1)
+
+	const goroutines = 50
+	results := make([]any, goroutines)
+	errs := make([]error, goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		done.Add(1)
+		go func(i int) {
+			defer done.Done()
+			start.Wait()
+			results[i], errs[i] = c.GetOrLoad("key", func() (any, error) {
+				atomic.AddInt32(&calls, 1)
+				return "value", nil
+			})
+		}(i)
+	}
+
+	start.Done()
+	done.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("loader called %d times, want 1", got)
+	}
+	for i := range results {
+		if errs[i] != nil {
+			t.Fatalf("goroutine %d: unexpected error: %v", i, errs[i])
+		}
+		if results[i] != "value" {
+			t.Fatalf("goroutine %d: got %v, want %q", i, results[i], "value")
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package memory
+
+import "container/list"
+
+// EvictionPolicy decides which entry to evict when a Cache is at
+// capacity and lets the Cache notify it of accesses, so alternative
+// strategies (LFU, TinyLFU, ...) can be swapped in for the default LRU
+// behavior via Cache.SetEvictionPolicy.
+type EvictionPolicy interface {
+	// Touch is called whenever key's element is read or written.
+	Touch(ll *list.List, ele *list.Element)
+	// Victim returns the element that should be evicted next, or nil
+	// if ll is empty.
+	Victim(ll *list.List) *list.Element
+}
+
+// lruPolicy is the default EvictionPolicy: the most recently touched
+// element is kept at the front of the list, and the victim is always
+// the element at the back.
+type lruPolicy struct{}
+
+func (lruPolicy) Touch(ll *list.List, ele *list.Element) {
+	ll.MoveToFront(ele)
+}
+
+func (lruPolicy) Victim(ll *list.List) *list.Element {
+	return ll.Back()
+}
@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/isofinly/cachease/cache"
+)
+
+// Config is the typed configuration accepted by the "memory" backend
+// factory registered with cache.Register. It mirrors the parameters
+// newCache already took positionally, but decoded from JSON so the
+// backend can be selected by name via cache.NewCache.
+type Config struct {
+	// Capacity is the maximum number of elements the cache will hold
+	// before the LRU eviction kicks in. Defaults to DEFAULT_CAP.
+	Capacity int64 `json:"capacity"`
+	// DefaultExpiration is applied to entries written via Set. Defaults
+	// to DEFAULT_EXPIRATION.
+	DefaultExpiration time.Duration `json:"defaultExpiration"`
+	// CleanDuration controls how often the background cleaner sweeps
+	// expired entries. Defaults to DEFAULT_CLEAN_DURATION.
+	CleanDuration time.Duration `json:"cleanDuration"`
+}
+
+func init() {
+	cache.Register("memory", newFromConfigJSON)
+}
+
+func newFromConfigJSON(configJSON string) (cache.Store, error) {
+	cfg := Config{
+		Capacity:          DEFAULT_CAP,
+		DefaultExpiration: DEFAULT_EXPIRATION,
+		CleanDuration:     DEFAULT_CLEAN_DURATION,
+	}
+	if configJSON != "" {
+		if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+			return nil, err
+		}
+	}
+	return NewFromConfig(cfg)
+}
+
+// NewFromConfig creates a Cache from a typed Config, applying defaults
+// for any zero-valued fields.
+//
+// Parameters:
+//
+//	cfg Config: the backend configuration.
+//
+// Returns:
+//
+//	(*Cache, error): the cache and an error, if any.
+func NewFromConfig(cfg Config) (*Cache, error) {
+	capacity := cfg.Capacity
+	if capacity == 0 {
+		capacity = DEFAULT_CAP
+	}
+	expiration := cfg.DefaultExpiration
+	if expiration == 0 {
+		expiration = DEFAULT_EXPIRATION
+	}
+	cleanDuration := cfg.CleanDuration
+	if cleanDuration == 0 {
+		cleanDuration = DEFAULT_CLEAN_DURATION
+	}
+	return newCache(capacity, expiration, cleanDuration)
+}
+
+// Set stores value under key using the cache's default expiration. It
+// implements cache.Store.
+func (c *Cache) Set(key string, value any) error {
+	return c.Put(key, value)
+}
+
+// SetEx stores value under key with an explicit expiration, overriding
+// the cache's default. It implements cache.Store.
+func (c *Cache) SetEx(key string, value any, expiration time.Duration) error {
+	expire := time.Now().Add(expiration).UnixNano()
+	lastHit := time.Now().UnixNano()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if node, ok := c.elements[key]; ok {
+		ele := node.Value.(*Elem)
+		ele.V = value
+		ele.Expiration = expire
+		ele.LastHit = lastHit
+		c.policy.Touch(c.ll, node)
+		return nil
+	}
+
+	if c.size+1 > c.capacity {
+		c.evict()
+	}
+
+	ele := c.newElem(key, value, expire, lastHit)
+	node := c.ll.PushFront(ele)
+	c.elements[key] = node
+	c.size++
+	return nil
+}
+
+// Delete removes key from the cache. It implements cache.Store.
+func (c *Cache) Delete(key string) error {
+	_, err := c.Remove(key)
+	return err
+}
+
+// Exists reports whether key is present in the cache. It implements
+// cache.Store.
+func (c *Cache) Exists(key string) (bool, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	_, ok := c.elements[key]
+	return ok, nil
+}
+
+// MGet retrieves the values stored under the given keys. Missing and
+// expired keys are omitted from the result. It implements cache.Store.
+func (c *Cache) MGet(keys ...string) (map[string]any, error) {
+	now := time.Now().UnixNano()
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	result := make(map[string]any, len(keys))
+	for _, key := range keys {
+		if node, ok := c.elements[key]; ok {
+			ele := node.Value.(*Elem)
+			if ele.Expiration > 0 && now > ele.Expiration {
+				continue
+			}
+			result[key] = ele.V
+		}
+	}
+	return result, nil
+}
+
+// MDel removes the given keys from the cache and returns the number of
+// keys that were actually present. It implements cache.Store.
+func (c *Cache) MDel(keys ...string) (int, error) {
+	deleted := 0
+	for _, key := range keys {
+		found, err := c.Remove(key)
+		if err != nil {
+			return deleted, err
+		}
+		if found {
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// Clear removes every entry from the cache. It implements cache.Store
+// as an alias of Flush.
+func (c *Cache) Clear() error {
+	return c.Flush()
+}
@@ -0,0 +1,72 @@
+package redis
+
+import (
+	"context"
+	"log"
+)
+
+// Publish publishes message on the given Redis pub/sub channel.
+//
+// Parameters:
+//
+//	ctx context.Context: governs cancellation and deadlines for the call.
+//	channel string: the channel to publish on.
+//	message string: the payload to publish.
+//
+// Returns:
+//
+//	error: an error if the publish failed.
+func (cache *CacheConn) Publish(ctx context.Context, channel string, message string) error {
+	if err := cache.client.Publish(ctx, channel, message).Err(); err != nil {
+		log.Println("Error while publishing to channel: ", err)
+		return err
+	}
+	return nil
+}
+
+// Subscribe subscribes to the given Redis pub/sub channel and invokes
+// onMessage for every payload received, until stop is closed or ctx is
+// cancelled.
+//
+// It dedicates a single connection to the subscription for its entire
+// lifetime, so callers should not call Subscribe more often than the
+// number of peers they intend to keep subscribed.
+//
+// Parameters:
+//
+//	ctx context.Context: governs the lifetime of the subscription.
+//	channel string: the channel to subscribe to.
+//	onMessage func(payload string): invoked for each message received.
+//	stop <-chan struct{}: closing it ends the subscription.
+//
+// Returns:
+//
+//	error: an error if the subscription could not be established.
+func (cache *CacheConn) Subscribe(ctx context.Context, channel string, onMessage func(payload string), stop <-chan struct{}) error {
+	sub := cache.client.Subscribe(ctx, channel)
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return err
+	}
+
+	go func() {
+		defer sub.Close()
+
+		msgs := sub.Channel()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					return
+				}
+				onMessage(msg.Payload)
+			}
+		}
+	}()
+
+	return nil
+}
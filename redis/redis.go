@@ -1,24 +1,38 @@
 package redis
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"time"
 
-	"github.com/gomodule/redigo/redis"
+	"github.com/redis/go-redis/v9"
 
 	"github.com/isofinly/cachease/config"
+	"github.com/isofinly/cachease/singleflight"
 )
 
 type CacheConn struct {
-	pool *redis.Pool
+	client     *redis.Client
+	loads      singleflight.Group
+	defaultTTL time.Duration
 }
 
-var Cache CacheConn
+// Cache is a *CacheConn rather than a CacheConn: CacheConn holds a
+// singleflight.Group, which carries a sync.Mutex, so copying a *conn
+// into a value here would leave callers operating on a mutex distinct
+// from the one the real connection uses.
+var Cache *CacheConn
 var cacheExp int
 
-// RedisPoolInit initializes the Redis connection pool.
+// RedisPoolInit initializes the Redis client.
+//
+// Deprecated: prefer NewFromConfig with an explicit Config, or
+// cache.NewCache("redis", configJSON) via the adapter registry.
+// RedisPoolInit is kept for callers that still configure the client
+// from the CACHE_TTL environment variable, and populates the
+// package-level Cache variable for backward compatibility.
 //
 // Parameters:
 //
@@ -28,40 +42,32 @@ var cacheExp int
 //
 //	None.
 func RedisPoolInit(redisHost string) {
-	var err error
-	cacheExp, err = strconv.Atoi(config.GetConfig("CACHE_TTL"))
+	ttl, err := strconv.Atoi(config.GetConfig("CACHE_TTL"))
 	if err != nil {
 		log.Println("Error while parsing cache TTL: ", err)
-		cacheExp = 20 // default value
+		ttl = 20 // default value
 		log.Println("Using default cache TTL (20 seconds)")
 	}
+	cacheExp = ttl
 
-	Cache = CacheConn{
-		pool: &redis.Pool{
-			MaxIdle:     10,
-			IdleTimeout: 60 * time.Second,
-			Dial: func() (redis.Conn, error) {
-				return redis.Dial("tcp", redisHost)
-			},
-		},
-	}
+	conn, _ := NewFromConfig(Config{
+		Host:       redisHost,
+		DefaultTTL: time.Duration(ttl) * time.Second,
+	})
+	Cache = conn
 }
 
-// ClearCache clears the entire cache
+// ClearCache clears the entire cache.
 //
 // Parameters:
 //
-//	None.
+//	ctx context.Context: governs cancellation and deadlines for the call.
 //
 // Returns:
 //
 //	None.
-func (cache *CacheConn) ClearCache() error {
-	conn := cache.pool.Get()
-	defer conn.Close()
-
-	_, err := conn.Do("FLUSHALL")
-	if err != nil {
+func (cache *CacheConn) ClearCache(ctx context.Context) error {
+	if err := cache.client.FlushAll(ctx).Err(); err != nil {
 		log.Println("Error while clearing cache: ", err)
 		return err
 	}
@@ -69,27 +75,23 @@ func (cache *CacheConn) ClearCache() error {
 	return nil
 }
 
-// SetDetails sets the details of a given key in the cache.
+// SetDetails sets the details of a given key in the cache, using the
+// connection's configured default TTL.
 //
 // Parameters:
 //
+//	ctx context.Context: governs cancellation and deadlines for the call.
 //	key string: the key of the cache entry.
 //	value interface{}: the value to be associated with the key.
 //
 // Returns:
 //
 //	error: an error if there was a problem setting the key or its expiry.
-func (cache *CacheConn) SetDetails(key, value any) error {
-	conn := cache.pool.Get()
-	defer conn.Close()
-
-	reply, err := conn.Do("SETEX", key, cacheExp, value)
-	if err != nil {
+func (cache *CacheConn) SetDetails(ctx context.Context, key string, value any) error {
+	if err := cache.client.SetEx(ctx, key, value, cache.defaultTTL).Err(); err != nil {
 		log.Println("Error while setting key: ", err)
 		return err
 	}
-	log.Println("Cache server reply on key set: ", reply)
-
 	return nil
 }
 
@@ -97,6 +99,7 @@ func (cache *CacheConn) SetDetails(key, value any) error {
 //
 // Parameters:
 //
+//	ctx context.Context: governs cancellation and deadlines for the call.
 //	key string: The key to set.
 //	value any: The value to set.
 //	expiration int: The expiration time in seconds.
@@ -104,17 +107,11 @@ func (cache *CacheConn) SetDetails(key, value any) error {
 // Returns:
 //
 //	error: An error, if any.
-func (cache *CacheConn) SetDetailsWithExp(key string, value any, expiration int) error {
-	conn := cache.pool.Get()
-	defer conn.Close()
-
-	reply, err := conn.Do("SETEX", key, expiration, value)
-	if err != nil {
+func (cache *CacheConn) SetDetailsWithExp(ctx context.Context, key string, value any, expiration int) error {
+	if err := cache.client.SetEx(ctx, key, value, time.Duration(expiration)*time.Second).Err(); err != nil {
 		log.Println("Error while setting key: ", err)
 		return err
 	}
-	log.Println("Cache server reply on key set: ", reply)
-
 	return nil
 }
 
@@ -122,51 +119,86 @@ func (cache *CacheConn) SetDetailsWithExp(key string, value any, expiration int)
 //
 // Parameters:
 //
+//	ctx context.Context: governs cancellation and deadlines for the call.
 //	key string: the key for which to retrieve the details.
 //
 // Returns:
 //
 //	string: the details for the given key.
 //	error: an error if there was a problem retrieving the details.
-func (cache *CacheConn) GetDetails(key string) (string, error) {
-	conn := cache.pool.Get()
-	defer conn.Close()
-	reply, err := redis.String(conn.Do("GET", key))
+func (cache *CacheConn) GetDetails(ctx context.Context, key string) (string, error) {
+	reply, err := cache.client.Get(ctx, key).Result()
 	if err != nil {
-		log.Println("An error occurred while fetching key from cache", err.Error())
+		if err != redis.Nil {
+			log.Println("An error occurred while fetching key from cache", err.Error())
+		}
 		return "", err
 	}
 	return reply, nil
 }
 
+// GetOrLoad retrieves the value for key, calling loader to produce it
+// on a cache miss and storing the result with SetDetails. Concurrent
+// GetOrLoad calls for the same key are coalesced so loader runs at
+// most once at a time; the rest of the callers block and share its
+// result, preventing a thundering herd against whatever loader fetches
+// from.
+//
+// Parameters:
+//
+//	ctx context.Context: governs cancellation and deadlines for the call.
+//	key (string): The key to retrieve or populate.
+//	loader (func() (any, error)): Invoked on a cache miss to produce the value.
+//
+// Returns:
+//
+//	(any, error): The cached or freshly loaded value, or an error if
+//	the cache lookup or loader failed.
+func (cache *CacheConn) GetOrLoad(ctx context.Context, key string, loader func() (any, error)) (any, error) {
+	if value, err := cache.GetDetails(ctx, key); err == nil {
+		return value, nil
+	}
+
+	return cache.loads.Do(key, func() (any, error) {
+		if value, err := cache.GetDetails(ctx, key); err == nil {
+			return value, nil
+		}
+
+		value, err := loader()
+		if err != nil {
+			return nil, err
+		}
+		if err := cache.SetDetails(ctx, key, value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	})
+}
+
 // GetManyDetails fetches the values of the specified keys from the cache.
 //
 // Parameters:
 //
+//	ctx context.Context: governs cancellation and deadlines for the call.
 //	keys ...string: The keys to fetch.
 //
 // Returns:
 //
 //	(map[string]string, error): A map of key-value pairs
 //	error: an error if there was a problem retrieving the details.
-func (cache *CacheConn) GetManyDetails(keys ...string) (map[string]string, error) {
-	conn := cache.pool.Get()
-	defer conn.Close()
-
-	args := make([]interface{}, len(keys))
-	for i, key := range keys {
-		args[i] = key
-	}
-
-	values, err := redis.Strings(conn.Do("MGET", args...))
+func (cache *CacheConn) GetManyDetails(ctx context.Context, keys ...string) (map[string]string, error) {
+	values, err := cache.client.MGet(ctx, keys...).Result()
 	if err != nil {
 		log.Println("An error occurred while fetching keys from cache", err.Error())
 		return nil, err
 	}
 
-	result := make(map[string]string)
+	result := make(map[string]string, len(keys))
 	for i, key := range keys {
-		result[key] = values[i]
+		if values[i] == nil {
+			continue
+		}
+		result[key] = fmt.Sprint(values[i])
 	}
 
 	return result, nil
@@ -176,16 +208,15 @@ func (cache *CacheConn) GetManyDetails(keys ...string) (map[string]string, error
 //
 // Parameters:
 //
+//	ctx context.Context: governs cancellation and deadlines for the call.
 //	key string: the key to check in the cache.
 //
 // Returns:
 //
 //	bool: true if the key exists in the cache, false otherwise.
 //	error: an error if something went wrong while checking the key in the cache.
-func (cache *CacheConn) IfExistsInCache(key string) (bool, error) {
-	conn := cache.pool.Get()
-	defer conn.Close()
-	exists, err := redis.Int(conn.Do("EXISTS", key))
+func (cache *CacheConn) IfExistsInCache(ctx context.Context, key string) (bool, error) {
+	exists, err := cache.client.Exists(ctx, key).Result()
 	if err != nil {
 		log.Println("An error occurred while checking if the key exists in cache", err.Error())
 		return false, err
@@ -196,55 +227,44 @@ func (cache *CacheConn) IfExistsInCache(key string) (bool, error) {
 		return true, nil
 	}
 
-	return false, fmt.Errorf("key doesn't exists")
-
+	return false, nil
 }
 
 // DeleteKey deletes a key from the cache.
 //
 // Parameters:
 //
+//	ctx context.Context: governs cancellation and deadlines for the call.
 //	key string: the key to be deleted from the cache.
 //
 // Returns:
 //
 //	bool: true if the key was successfully deleted, false otherwise.
 //	error: an error if any occurred during the deletion process.
-func (cache *CacheConn) DeleteKey(key string) (bool, error) {
-	conn := cache.pool.Get()
-	defer conn.Close()
-	_, err := redis.Int(conn.Do("DEL", key))
-	if err != nil {
+func (cache *CacheConn) DeleteKey(ctx context.Context, key string) (bool, error) {
+	if _, err := cache.client.Del(ctx, key).Result(); err != nil {
 		log.Println("An error occurred while deleting key from cache: ", err.Error())
 		return false, err
 	}
 	return true, nil
-
 }
 
 // DeleteKeys deletes the specified keys from the cache.
 //
 // Parameters:
 //
+//	ctx context.Context: governs cancellation and deadlines for the call.
 //	keys ...string: The keys to delete.
 //
 // Returns:
 //
 //	int: The number of keys deleted.
 //	error: an error if there was a problem retrieving the details.
-func (cache *CacheConn) DeleteKeys(keys ...string) (int, error) {
-	conn := cache.pool.Get()
-	defer conn.Close()
-
-	args := make([]interface{}, len(keys))
-	for i, key := range keys {
-		args[i] = key
-	}
-
-	count, err := redis.Int(conn.Do("DEL", args...))
+func (cache *CacheConn) DeleteKeys(ctx context.Context, keys ...string) (int, error) {
+	count, err := cache.client.Del(ctx, keys...).Result()
 	if err != nil {
 		log.Println("An error occurred while deleting keys from cache: ", err.Error())
 		return 0, err
 	}
-	return count, nil
+	return int(count), nil
 }
@@ -0,0 +1,149 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/isofinly/cachease/cache"
+)
+
+// Config is the typed configuration accepted by the "redis" backend
+// factory registered with cache.Register. It replaces the previous
+// env-var-only setup in RedisPoolInit, though RedisPoolInit is kept as
+// a thin wrapper over it for existing callers.
+type Config struct {
+	// Host is the "host:port" address of the Redis server to dial.
+	Host string `json:"host"`
+	// DefaultTTL is applied to entries written via Set. Defaults to 20s.
+	DefaultTTL time.Duration `json:"defaultTTL"`
+	// MaxIdle is the maximum number of connections kept in the client's
+	// pool.
+	MaxIdle int `json:"maxIdle"`
+	// IdleTimeout closes idle connections after this duration.
+	IdleTimeout time.Duration `json:"idleTimeout"`
+}
+
+func init() {
+	cache.Register("redis", newFromConfigJSON)
+}
+
+func newFromConfigJSON(configJSON string) (cache.Store, error) {
+	cfg := Config{
+		DefaultTTL:  20 * time.Second,
+		MaxIdle:     10,
+		IdleTimeout: 60 * time.Second,
+	}
+	if configJSON != "" {
+		if err := json.Unmarshal([]byte(configJSON), &cfg); err != nil {
+			return nil, err
+		}
+	}
+	return NewFromConfig(cfg)
+}
+
+// NewFromConfig creates a CacheConn from a typed Config, applying
+// defaults for any zero-valued fields.
+//
+// Parameters:
+//
+//	cfg Config: the backend configuration.
+//
+// Returns:
+//
+//	(*CacheConn, error): the connection wrapper and an error, if any.
+func NewFromConfig(cfg Config) (*CacheConn, error) {
+	if cfg.DefaultTTL == 0 {
+		cfg.DefaultTTL = 20 * time.Second
+	}
+	if cfg.MaxIdle == 0 {
+		cfg.MaxIdle = 10
+	}
+	if cfg.IdleTimeout == 0 {
+		cfg.IdleTimeout = 60 * time.Second
+	}
+
+	conn := &CacheConn{
+		client: redis.NewClient(&redis.Options{
+			Addr:            cfg.Host,
+			PoolSize:        cfg.MaxIdle,
+			ConnMaxIdleTime: cfg.IdleTimeout,
+		}),
+		defaultTTL: cfg.DefaultTTL,
+	}
+	return conn, nil
+}
+
+// The methods below implement cache.Store, which predates this
+// package's migration to context-aware methods. They are a thin
+// compatibility shim for callers that haven't migrated yet: each one
+// runs its ctx-aware counterpart with context.Background(). New code
+// should call the ctx-aware methods (SetDetails, GetDetails, ...)
+// directly instead.
+
+// Get retrieves the value stored under key. It implements cache.Store,
+// translating redis.Nil into cache.ErrNotFound.
+func (c *CacheConn) Get(key string) (any, error) {
+	value, err := c.GetDetails(context.Background(), key)
+	if err == redis.Nil {
+		return nil, cache.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Set stores value under key using the connection's default TTL. It
+// implements cache.Store.
+func (cache *CacheConn) Set(key string, value any) error {
+	return cache.SetDetails(context.Background(), key, value)
+}
+
+// SetEx stores value under key with an explicit expiration. It
+// implements cache.Store.
+func (cache *CacheConn) SetEx(key string, value any, expiration time.Duration) error {
+	return cache.SetDetailsWithExp(context.Background(), key, value, int(expiration.Seconds()))
+}
+
+// Delete removes key from the cache. It implements cache.Store.
+func (cache *CacheConn) Delete(key string) error {
+	_, err := cache.DeleteKey(context.Background(), key)
+	return err
+}
+
+// Exists reports whether key is present in the cache. It implements
+// cache.Store: IfExistsInCache already returns (false, nil) for a
+// missing key and reserves a non-nil error for real failures, so this
+// just passes both straight through.
+func (cache *CacheConn) Exists(key string) (bool, error) {
+	return cache.IfExistsInCache(context.Background(), key)
+}
+
+// MGet retrieves the values stored under the given keys. Missing keys
+// are omitted from the result. It implements cache.Store.
+func (cache *CacheConn) MGet(keys ...string) (map[string]any, error) {
+	details, err := cache.GetManyDetails(context.Background(), keys...)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]any, len(details))
+	for k, v := range details {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// MDel removes the given keys from the cache and returns the number of
+// keys that were actually deleted. It implements cache.Store.
+func (cache *CacheConn) MDel(keys ...string) (int, error) {
+	return cache.DeleteKeys(context.Background(), keys...)
+}
+
+// Clear removes every entry from the cache. It implements cache.Store
+// as an alias of ClearCache.
+func (cache *CacheConn) Clear() error {
+	return cache.ClearCache(context.Background())
+}
@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/isofinly/cachease/cache"
+)
+
+// newTestConn dials a local Redis instance and skips the test if one
+// isn't reachable, since this package has no fake/mock for *redis.Client
+// to exercise the go-redis/v9 wire path against.
+func newTestConn(t *testing.T) *CacheConn {
+	t.Helper()
+
+	conn, err := NewFromConfig(Config{Host: "127.0.0.1:6379"})
+	if err != nil {
+		t.Fatalf("NewFromConfig: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := conn.client.Ping(ctx).Err(); err != nil {
+		t.Skipf("no local Redis reachable at 127.0.0.1:6379: %v", err)
+	}
+	return conn
+}
+
+func TestCacheConn_SetGetDeleteExists(t *testing.T) {
+	conn := newTestConn(t)
+	key := "cachease-test:store"
+	defer conn.Delete(key)
+
+	if err := conn.SetEx(key, "value", time.Minute); err != nil {
+		t.Fatalf("SetEx: %v", err)
+	}
+
+	got, err := conn.Get(key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got != "value" {
+		t.Fatalf("Get = %v, want %q", got, "value")
+	}
+
+	found, err := conn.Exists(key)
+	if err != nil {
+		t.Fatalf("Exists: %v", err)
+	}
+	if !found {
+		t.Fatal("Exists = false, want true")
+	}
+
+	if err := conn.Delete(key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := conn.Get(key); err != cache.ErrNotFound {
+		t.Fatalf("Get after Delete: got err %v, want cache.ErrNotFound", err)
+	}
+	if found, err := conn.Exists(key); err != nil || found {
+		t.Fatalf("Exists after Delete: got (%v, %v), want (false, nil)", found, err)
+	}
+}
+
+func TestCacheConn_GetOrLoad_CoalescesConcurrentMisses(t *testing.T) {
+	conn := newTestConn(t)
+	key := "cachease-test:getorload"
+	_ = conn.Delete(key)
+	defer conn.Delete(key)
+
+	ctx := context.Background()
+	var calls int32
+	var start sync.WaitGroup
+	var done sync.WaitGroup
+	start.Add(1)
+
+	const goroutines = 10
+	results := make([]any, goroutines)
+	errs := make([]error, goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		done.Add(1)
+		go func(i int) {
+			defer done.Done()
+			start.Wait()
+			results[i], errs[i] = conn.GetOrLoad(ctx, key, func() (any, error) {
+				atomic.AddInt32(&calls, 1)
+				return "loaded", nil
+			})
+		}(i)
+	}
+
+	start.Done()
+	done.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("loader called %d times, want 1", got)
+	}
+	for i := range results {
+		if errs[i] != nil {
+			t.Fatalf("goroutine %d: unexpected error: %v", i, errs[i])
+		}
+		if results[i] != "loaded" {
+			t.Fatalf("goroutine %d: got %v, want %q", i, results[i], "loaded")
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Package singleflight provides a duplicate-call suppression mechanism
+// for a group of callers, so that only one in-flight call for a given
+// key is ever executed while the rest wait for its result. It mirrors
+// the semantics of golang.org/x/sync/singleflight, reimplemented
+// in-repo to avoid adding a dependency for a single small primitive.
+package singleflight
+
+import "sync"
+
+// call is an in-flight or completed Do call.
+type call struct {
+	wg  sync.WaitGroup
+	val any
+	err error
+}
+
+// Group coalesces concurrent calls for the same key so that only one
+// of them executes fn; the rest block until it completes and share its
+// result. The zero value is ready to use.
+type Group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// Do executes fn, making sure only one execution is in flight for a
+// given key at a time. If a duplicate call comes in, it waits for the
+// original to complete and receives the same result.
+//
+// Parameters:
+//
+//	key string: the deduplication key.
+//	fn func() (any, error): the function to execute.
+//
+// Returns:
+//
+//	(any, error): the result of fn, shared across all callers for key.
+func (g *Group) Do(key string, fn func() (any, error)) (any, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+
+	c := new(call)
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.val, c.err = fn()
+	c.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return c.val, c.err
+}
@@ -0,0 +1,265 @@
+// Package tiered composes an in-process memory.Cache (L1) in front of a
+// redis.CacheConn (L2) into a single two-level cache: reads check L1
+// first and fall through to L2 on a miss, writes go write-through to
+// both tiers, and Redis pub/sub keeps every instance's L1 coherent.
+package tiered
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/isofinly/cachease/cache"
+	"github.com/isofinly/cachease/memory"
+	"github.com/isofinly/cachease/redis"
+)
+
+// DEFAULT_CHANNEL is the Redis pub/sub channel used to announce key
+// invalidations when no channel is given in TieredOpts.
+const DEFAULT_CHANNEL = "cachease:invalidate"
+
+// negative marks a key that was confirmed absent from L2, so repeated
+// lookups don't keep hammering Redis for a key that doesn't exist.
+type negative struct{}
+
+// TieredOpts configures a TieredCache.
+type TieredOpts struct {
+	// Channel is the Redis pub/sub channel peers use to announce
+	// invalidated keys. Defaults to DEFAULT_CHANNEL.
+	Channel string
+	// L1TTL is the expiration applied to entries populated into the
+	// in-process tier. Defaults to memory.DEFAULT_EXPIRATION.
+	L1TTL time.Duration
+	// L2TTL is the expiration applied to entries written to Redis.
+	// Zero means Redis's own default (see redis.Config.DefaultTTL).
+	L2TTL time.Duration
+	// NegativeTTL, if non-zero, caches L2 misses in L1 for this long so
+	// repeated lookups of a missing key don't round-trip to Redis.
+	NegativeTTL time.Duration
+}
+
+// TieredCache is a two-tier cache.Store: an in-process memory.Cache
+// (L1) backed by a shared redis.CacheConn (L2).
+type TieredCache struct {
+	l1        *memory.Cache
+	l2        *redis.CacheConn
+	opts      TieredOpts
+	stop      chan struct{}
+	origin    string
+	closeOnce sync.Once
+}
+
+// newOrigin returns a random id identifying this TieredCache instance
+// on the invalidation channel, so it can recognize and ignore its own
+// published messages.
+func newOrigin() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "anon"
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// NewTieredCache builds a TieredCache from an already-constructed L1
+// and L2, and subscribes to opts.Channel so peers' writes evict this
+// instance's L1 copy.
+//
+// Parameters:
+//
+//	l1 *memory.Cache: the in-process tier.
+//	l2 *redis.CacheConn: the shared tier.
+//	opts TieredOpts: tuning options; zero value is a valid default.
+//
+// Returns:
+//
+//	(*TieredCache, error): the tiered cache, or an error if the pub/sub
+//	subscription could not be established.
+func NewTieredCache(l1 *memory.Cache, l2 *redis.CacheConn, opts TieredOpts) (*TieredCache, error) {
+	if opts.Channel == "" {
+		opts.Channel = DEFAULT_CHANNEL
+	}
+	if opts.L1TTL == 0 {
+		opts.L1TTL = memory.DEFAULT_EXPIRATION
+	}
+
+	tc := &TieredCache{
+		l1:     l1,
+		l2:     l2,
+		opts:   opts,
+		stop:   make(chan struct{}),
+		origin: newOrigin(),
+	}
+
+	if err := l2.Subscribe(context.Background(), opts.Channel, tc.onInvalidate, tc.stop); err != nil {
+		return nil, err
+	}
+	return tc, nil
+}
+
+// onInvalidate handles a message received on the invalidation channel.
+// Messages are tagged "<origin>:<key>" by publishInvalidation; a
+// message carrying this instance's own origin is its own write coming
+// back around the pub/sub loop and is ignored, so a read-after-write on
+// the same instance doesn't needlessly evict the L1 entry it just
+// populated.
+func (tc *TieredCache) onInvalidate(payload string) {
+	origin, key, ok := strings.Cut(payload, ":")
+	if !ok || origin == tc.origin {
+		return
+	}
+	_ = tc.l1.Delete(key)
+}
+
+// publishInvalidation announces that key changed on the tiered cache's
+// invalidation channel, tagged with this instance's origin so its own
+// subscription can ignore the message.
+func (tc *TieredCache) publishInvalidation(key string) error {
+	return tc.l2.Publish(context.Background(), tc.opts.Channel, tc.origin+":"+key)
+}
+
+// Get checks L1 first, falling through to L2 on a miss and populating
+// L1 with the result. It implements cache.Store.
+func (tc *TieredCache) Get(key string) (any, error) {
+	if value, err := tc.l1.Get(key); err == nil {
+		if _, isNegative := value.(negative); isNegative {
+			return nil, cache.ErrNotFound
+		}
+		return value, nil
+	}
+
+	value, err := tc.l2.Get(key)
+	if err == cache.ErrNotFound {
+		if tc.opts.NegativeTTL > 0 {
+			_ = tc.l1.SetEx(key, negative{}, tc.opts.NegativeTTL)
+		}
+		return nil, cache.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_ = tc.l1.SetEx(key, value, tc.opts.L1TTL)
+	return value, nil
+}
+
+// Set writes value through to both L2 and L1, then publishes an
+// invalidation for key so peers evict their stale L1 copy. It
+// implements cache.Store.
+func (tc *TieredCache) Set(key string, value any) error {
+	return tc.SetEx(key, value, tc.opts.L2TTL)
+}
+
+// SetEx writes value through to both tiers with an explicit L2
+// expiration, then publishes an invalidation for key. It implements
+// cache.Store.
+func (tc *TieredCache) SetEx(key string, value any, expiration time.Duration) error {
+	if expiration > 0 {
+		if err := tc.l2.SetEx(key, value, expiration); err != nil {
+			return err
+		}
+	} else if err := tc.l2.Set(key, value); err != nil {
+		return err
+	}
+
+	if err := tc.l1.SetEx(key, value, tc.opts.L1TTL); err != nil {
+		return err
+	}
+
+	return tc.publishInvalidation(key)
+}
+
+// Delete removes key from both tiers and publishes an invalidation so
+// peers evict their L1 copy. It implements cache.Store.
+func (tc *TieredCache) Delete(key string) error {
+	if err := tc.l2.Delete(key); err != nil {
+		return err
+	}
+	if err := tc.l1.Delete(key); err != nil {
+		return err
+	}
+	return tc.publishInvalidation(key)
+}
+
+// Exists reports whether key is present in either tier. It implements
+// cache.Store.
+//
+// It checks L1 via Get rather than Exists: a key negative-cached by Get
+// on an L2 miss is present as an L1 map entry but must be reported
+// absent here too, matching what Get itself would return for it.
+func (tc *TieredCache) Exists(key string) (bool, error) {
+	if value, err := tc.l1.Get(key); err == nil {
+		_, isNegative := value.(negative)
+		return !isNegative, nil
+	}
+	return tc.l2.Exists(key)
+}
+
+// MGet retrieves the values stored under the given keys, checking L1
+// before falling through to L2 per key. It implements cache.Store.
+func (tc *TieredCache) MGet(keys ...string) (map[string]any, error) {
+	result := make(map[string]any, len(keys))
+	var misses []string
+	for _, key := range keys {
+		if value, err := tc.l1.Get(key); err == nil {
+			if _, isNegative := value.(negative); !isNegative {
+				result[key] = value
+				continue
+			}
+		}
+		misses = append(misses, key)
+	}
+	if len(misses) == 0 {
+		return result, nil
+	}
+
+	fromL2, err := tc.l2.MGet(misses...)
+	if err != nil {
+		return result, err
+	}
+	for key, value := range fromL2 {
+		result[key] = value
+		_ = tc.l1.SetEx(key, value, tc.opts.L1TTL)
+	}
+	return result, nil
+}
+
+// MDel removes the given keys from both tiers and publishes an
+// invalidation for each. It implements cache.Store.
+func (tc *TieredCache) MDel(keys ...string) (int, error) {
+	deleted, err := tc.l2.MDel(keys...)
+	if err != nil {
+		return deleted, err
+	}
+	for _, key := range keys {
+		_ = tc.l1.Delete(key)
+		if pubErr := tc.publishInvalidation(key); pubErr != nil {
+			return deleted, pubErr
+		}
+	}
+	return deleted, nil
+}
+
+// Clear empties both tiers. It implements cache.Store.
+//
+// Clearing L2 is not itself published on the invalidation channel:
+// peers only evict individual keys they're told about, so after a
+// Clear, other instances' L1 entries are invalidated lazily as their
+// own TTLs expire.
+func (tc *TieredCache) Clear() error {
+	if err := tc.l2.Clear(); err != nil {
+		return err
+	}
+	return tc.l1.Clear()
+}
+
+// Close stops the background pub/sub subscription. TieredCache is not
+// usable after Close. It is safe to call more than once; only the
+// first call stops the subscription.
+func (tc *TieredCache) Close() {
+	tc.closeOnce.Do(func() {
+		close(tc.stop)
+	})
+}
@@ -0,0 +1,34 @@
+package tiered
+
+import (
+	"testing"
+
+	"github.com/isofinly/cachease/cache"
+	"github.com/isofinly/cachease/memory"
+)
+
+// TestTieredCache_OnInvalidate_IgnoresSelfOrigin guards against the
+// self-invalidation bug: a write's own publish must not evict the L1
+// entry it just populated on the same instance, while an invalidation
+// from a peer (a different origin) still must.
+func TestTieredCache_OnInvalidate_IgnoresSelfOrigin(t *testing.T) {
+	l1, err := memory.NewCache()
+	if err != nil {
+		t.Fatalf("memory.NewCache: %v", err)
+	}
+	tc := &TieredCache{l1: l1, opts: TieredOpts{Channel: DEFAULT_CHANNEL}, origin: "self"}
+
+	if err := l1.Put("key", "value"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	tc.onInvalidate("self:key")
+	if _, err := l1.Get("key"); err != nil {
+		t.Fatalf("Get after self-originated invalidation: got err %v, want entry to survive", err)
+	}
+
+	tc.onInvalidate("peer:key")
+	if _, err := l1.Get("key"); err != cache.ErrNotFound {
+		t.Fatalf("Get after peer-originated invalidation: got err %v, want cache.ErrNotFound", err)
+	}
+}